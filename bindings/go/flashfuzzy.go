@@ -22,6 +22,7 @@ uint32_t ff_get_result_id(uint32_t index);
 uint32_t ff_get_result_score(uint32_t index);
 uint32_t ff_get_result_start(uint32_t index);
 uint32_t ff_get_result_end(uint32_t index);
+uint32_t ff_get_result_positions(uint32_t index, uint32_t* buf, uint32_t cap);
 void ff_reset(void);
 uint32_t ff_compact(void);
 uint32_t ff_get_string_pool_used(void);
@@ -38,6 +39,13 @@ type SearchResult struct {
 	Score float32
 	Start uint32
 	End   uint32
+
+	// Positions holds the individual matched character offsets, for
+	// highlighting non-contiguous fuzzy matches. Search leaves it nil
+	// (the caller can populate it via FlashFuzzy.Positions), since
+	// computing it costs an extra FFI round-trip most callers don't need;
+	// SearchExtended fills it in.
+	Positions []uint32
 }
 
 // FlashFuzzy is a high-performance fuzzy search engine
@@ -45,6 +53,15 @@ type FlashFuzzy struct {
 	threshold  float32
 	maxErrors  uint32
 	maxResults uint32
+
+	// records mirrors the text handed to Add/AddBatch so the Go layer can
+	// evaluate exact/prefix/suffix/negation clauses (see query.go) without
+	// round-tripping through the FFI for operators the Rust core doesn't
+	// know about.
+	records map[uint32]string
+
+	// scorer re-ranks raw FFI hits; see scorer.go.
+	scorer Scorer
 }
 
 // Options for creating a new FlashFuzzy instance
@@ -82,6 +99,8 @@ func New(opts Options) *FlashFuzzy {
 		threshold:  opts.Threshold,
 		maxErrors:  opts.MaxErrors,
 		maxResults: opts.MaxResults,
+		records:    make(map[uint32]string),
+		scorer:     NewFzfV2Scorer(),
 	}
 }
 
@@ -104,6 +123,10 @@ func (ff *FlashFuzzy) Add(id uint32, text string) bool {
 	C.ff_commit_write(C.uint32_t(len(textBytes)))
 	result := C.ff_add_record(C.uint32_t(id))
 
+	if result == 1 {
+		ff.records[id] = text
+	}
+
 	return result == 1
 }
 
@@ -152,17 +175,50 @@ func (ff *FlashFuzzy) Search(query string) []SearchResult {
 		}
 	}
 
+	ff.applyScorer(results, query)
+
 	return results
 }
 
+// Positions returns the individual character offsets that matched for the
+// resultIndex'th hit of the most recent plain Search call, for
+// highlighting non-contiguous fuzzy matches. It is lazy by design: call it
+// only for the results you're about to render, not the whole result set.
+// resultIndex counts hits in the order the Rust core returned them, which
+// is not the order of the slice Search returns once the Scorer has
+// re-sorted it. Positions reflects only the most recent Search call.
+//
+// Do not call Positions against results returned by SearchExtended: an
+// extended query can invoke the underlying Search zero, one, or many
+// times (once per fuzzy alternative/clause), each of which overwrites the
+// Rust core's result buffer, so there is no resultIndex that reliably
+// maps back to a particular SearchExtended result. SearchExtended instead
+// populates SearchResult.Positions itself; read it directly.
+func (ff *FlashFuzzy) Positions(resultIndex int) []uint32 {
+	buf := make([]uint32, 32)
+	n := uint32(C.ff_get_result_positions(C.uint32_t(resultIndex), (*C.uint32_t)(unsafe.Pointer(&buf[0])), C.uint32_t(len(buf))))
+
+	if int(n) > len(buf) {
+		buf = make([]uint32, n)
+		n = uint32(C.ff_get_result_positions(C.uint32_t(resultIndex), (*C.uint32_t)(unsafe.Pointer(&buf[0])), C.uint32_t(len(buf))))
+	}
+
+	return buf[:n]
+}
+
 // Remove removes a record by ID
 func (ff *FlashFuzzy) Remove(id uint32) bool {
-	return C.ff_remove_record(C.uint32_t(id)) == 1
+	removed := C.ff_remove_record(C.uint32_t(id)) == 1
+	if removed {
+		delete(ff.records, id)
+	}
+	return removed
 }
 
 // Reset clears all records
 func (ff *FlashFuzzy) Reset() {
 	C.ff_reset()
+	ff.records = make(map[uint32]string)
 }
 
 // Count returns the number of records
@@ -0,0 +1,105 @@
+package flashfuzzy
+
+import (
+	"strings"
+	"testing"
+)
+
+// checkResultInvariants validates the properties every SearchResult must
+// satisfy regardless of what bytes produced it: a well-formed span within
+// text, and a score in the documented [0,1] range. The Rust side receives
+// raw bytes via unsafe.Pointer slice aliasing (see Add/Search), so any
+// length mismatch there is undefined behavior today and these invariants
+// are the cheapest way to catch it before it corrupts memory silently.
+func checkResultInvariants(t *testing.T, text []byte, r SearchResult) {
+	t.Helper()
+	if r.Start > r.End {
+		t.Fatalf("Start %d > End %d for text %q", r.Start, r.End, text)
+	}
+	if int(r.End) > len(text) {
+		t.Fatalf("End %d exceeds text length %d for text %q", r.End, len(text), text)
+	}
+	if r.Score < 0 || r.Score > 1 {
+		t.Fatalf("Score %f out of [0,1] for text %q", r.Score, text)
+	}
+}
+
+func FuzzAddSearch(f *testing.F) {
+	seeds := [][2]string{
+		{"Wireless Headphones", "headphones"},
+		{"", "anything"},
+		{"a", ""},
+		{strings.Repeat("x", 5000), "x"},
+	}
+	for _, s := range seeds {
+		f.Add([]byte(s[0]), []byte(s[1]))
+	}
+
+	f.Fuzz(func(t *testing.T, text, query []byte) {
+		ff := New(DefaultOptions())
+		ff.Add(1, string(text))
+
+		results := ff.Search(string(query))
+		for _, r := range results {
+			checkResultInvariants(t, text, r)
+		}
+	})
+}
+
+func FuzzExtendedQuery(f *testing.F) {
+	seeds := [][2]string{
+		{"Wireless Headphones", "'wireless !headphones"},
+		{"foo/bar_baz", "^foo bar$"},
+		{"a | b", "a | b"},
+		{"", "!"},
+	}
+	for _, s := range seeds {
+		f.Add([]byte(s[0]), []byte(s[1]))
+	}
+
+	f.Fuzz(func(t *testing.T, text, query []byte) {
+		ff := New(DefaultOptions())
+		ff.Add(1, string(text))
+
+		results, err := ff.SearchExtended(string(query))
+		if err != nil {
+			return
+		}
+		for _, r := range results {
+			checkResultInvariants(t, text, r)
+		}
+	})
+}
+
+func FuzzUnicode(f *testing.F) {
+	seeds := [][2]string{
+		{"é combining acute", "é"},             // combining mark
+		{"\U0001F600 emoji record", "\U0001F600"},           // 4-byte rune
+		{string([]byte{0xff, 0xfe, 0x00, 0x41}), "A"},       // invalid UTF-8 sequence
+		{"foo\x00bar", "foo"},                               // embedded NUL
+		{strings.Repeat("\U0001F600", 2000), "\U0001F600"},  // very long multi-byte string
+	}
+	for _, s := range seeds {
+		f.Add([]byte(s[0]), []byte(s[1]))
+	}
+
+	f.Fuzz(func(t *testing.T, text, query []byte) {
+		ff := New(DefaultOptions())
+		added := ff.Add(1, string(text))
+
+		for _, r := range ff.Search(string(query)) {
+			checkResultInvariants(t, text, r)
+		}
+
+		if !added {
+			return
+		}
+
+		if !ff.Remove(1) {
+			t.Fatalf("Remove failed right after a successful Add for text %q", text)
+		}
+		if readded := ff.Add(1, string(text)); !readded {
+			t.Fatalf("Add after Remove behaved differently than the first Add for text %q", text)
+		}
+	})
+}
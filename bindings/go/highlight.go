@@ -0,0 +1,99 @@
+package flashfuzzy
+
+import (
+	"html"
+	"sort"
+	"strings"
+)
+
+// HighlightHTML wraps each run of consecutive matched byte offsets in text
+// with openTag/closeTag, HTML-escaping everything else. positions need not
+// be sorted or deduplicated; non-contiguous fuzzy matches (e.g. from
+// Positions) produce one tag pair per contiguous run.
+func HighlightHTML(text string, positions []uint32, openTag, closeTag string) string {
+	var b strings.Builder
+	walkMatchedRuns(text, positions, func(s, e int, matched bool) {
+		if matched {
+			b.WriteString(openTag)
+			b.WriteString(html.EscapeString(text[s:e]))
+			b.WriteString(closeTag)
+		} else {
+			b.WriteString(html.EscapeString(text[s:e]))
+		}
+	})
+	return b.String()
+}
+
+// HighlightANSI wraps each run of consecutive matched byte offsets in text
+// with the given ANSI escape code (e.g. "\x1b[1;33m"), resetting with
+// "\x1b[0m" after each run. It's the terminal-UI counterpart to
+// HighlightHTML for CLI fuzzy finders.
+func HighlightANSI(text string, positions []uint32, code string) string {
+	const reset = "\x1b[0m"
+	var b strings.Builder
+	walkMatchedRuns(text, positions, func(s, e int, matched bool) {
+		if matched {
+			b.WriteString(code)
+			b.WriteString(text[s:e])
+			b.WriteString(reset)
+		} else {
+			b.WriteString(text[s:e])
+		}
+	})
+	return b.String()
+}
+
+// walkMatchedRuns calls emit once per contiguous run of text, alternating
+// matched/unmatched spans in order, based on the (possibly unsorted,
+// possibly duplicated) byte offsets in positions.
+func walkMatchedRuns(text string, positions []uint32, emit func(start, end int, matched bool)) {
+	if len(positions) == 0 {
+		emit(0, len(text), false)
+		return
+	}
+
+	sorted := append([]uint32(nil), positions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	sorted = dedupeSorted(sorted)
+
+	cursor := 0
+	i := 0
+	for i < len(sorted) {
+		pos := int(sorted[i])
+		if pos < cursor || pos >= len(text) {
+			i++
+			continue
+		}
+		if pos > cursor {
+			emit(cursor, pos, false)
+		}
+
+		runEnd := pos + 1
+		i++
+		for i < len(sorted) && int(sorted[i]) == runEnd {
+			runEnd++
+			i++
+		}
+
+		emit(pos, runEnd, true)
+		cursor = runEnd
+	}
+
+	if cursor < len(text) {
+		emit(cursor, len(text), false)
+	}
+}
+
+// dedupeSorted removes adjacent duplicates from an already-sorted slice.
+// A duplicate position at a run boundary would otherwise consume the
+// merge walk's one-ahead lookahead and split a single contiguous run
+// into two (e.g. positions [2,2,3] splitting "cd" into "[c][d]").
+func dedupeSorted(sorted []uint32) []uint32 {
+	out := sorted[:0]
+	for i, p := range sorted {
+		if i == 0 || p != sorted[i-1] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
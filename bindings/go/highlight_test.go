@@ -0,0 +1,50 @@
+package flashfuzzy
+
+import "testing"
+
+func TestHighlightHTML(t *testing.T) {
+	got := HighlightHTML("keyboard", []uint32{0, 1, 2}, "<b>", "</b>")
+	want := "<b>key</b>board"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestHighlightHTMLNonContiguous(t *testing.T) {
+	got := HighlightHTML("keyboard", []uint32{0, 3, 4}, "<b>", "</b>")
+	want := "<b>k</b>ey<b>bo</b>ard"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestHighlightHTMLEscapesUnmatched(t *testing.T) {
+	got := HighlightHTML("a<b>", []uint32{0}, "<mark>", "</mark>")
+	want := "<mark>a</mark>&lt;b&gt;"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestHighlightHTMLDuplicatePositions(t *testing.T) {
+	got := HighlightHTML("abcde", []uint32{2, 2, 3}, "[", "]")
+	want := "ab[cd]e"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestHighlightANSI(t *testing.T) {
+	got := HighlightANSI("keyboard", []uint32{0, 1, 2}, "\x1b[33m")
+	want := "\x1b[33mkey\x1b[0mboard"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestHighlightNoPositions(t *testing.T) {
+	got := HighlightHTML("plain", nil, "<b>", "</b>")
+	if got != "plain" {
+		t.Errorf("got %q, want %q", got, "plain")
+	}
+}
@@ -0,0 +1,118 @@
+package flashfuzzy
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// IndexOptions configures IndexFrom and IndexFromChan.
+type IndexOptions struct {
+	// IDExtractor splits a line into an explicit record ID and its
+	// indexed text. If nil, IDs are auto-assigned sequentially starting
+	// at NextID and the line is indexed verbatim.
+	IDExtractor func(line string) (id uint32, text string)
+
+	// NextID is the first ID auto-assigned when IDExtractor is nil.
+	NextID uint32
+
+	// FlushEvery, if > 0, invokes Progress every FlushEvery successfully
+	// added records so long-running indexing jobs can report progress.
+	FlushEvery int
+
+	// Progress is called with the running add count and the current
+	// index stats every FlushEvery records, if both are set.
+	Progress func(added int, stats Stats)
+
+	// Context, if non-nil, is checked between records so a long-running
+	// index can be cancelled; ctx.Err() is returned once it's Done.
+	Context context.Context
+}
+
+// IndexFrom reads line-delimited records from r and adds each to the
+// index, the way fzf's reader subsystem feeds a producer from a pipe
+// (e.g. `find | flashfuzzy`). It returns the number of records added.
+func (ff *FlashFuzzy) IndexFrom(r io.Reader, opts IndexOptions) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	added := 0
+	nextID := opts.NextID
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return added, ctx.Err()
+		default:
+		}
+
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		id, text := nextID, line
+		if opts.IDExtractor != nil {
+			id, text = opts.IDExtractor(line)
+		} else {
+			nextID++
+		}
+
+		if ff.Add(id, text) {
+			added++
+			if opts.FlushEvery > 0 && opts.Progress != nil && added%opts.FlushEvery == 0 {
+				opts.Progress(added, ff.GetStats())
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return added, err
+	}
+	return added, nil
+}
+
+// IndexFromChan drains ch, adding each received string as a record the
+// same way IndexFrom does, until ch is closed or opts.Context is done.
+// It's the channel-driven counterpart to IndexFrom for producers that
+// already stream strings (e.g. a log tailer) instead of an io.Reader.
+func (ff *FlashFuzzy) IndexFromChan(ch <-chan string, opts IndexOptions) (int, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	added := 0
+	nextID := opts.NextID
+	for {
+		select {
+		case <-ctx.Done():
+			return added, ctx.Err()
+		case line, ok := <-ch:
+			if !ok {
+				return added, nil
+			}
+			if line == "" {
+				continue
+			}
+
+			id, text := nextID, line
+			if opts.IDExtractor != nil {
+				id, text = opts.IDExtractor(line)
+			} else {
+				nextID++
+			}
+
+			if ff.Add(id, text) {
+				added++
+				if opts.FlushEvery > 0 && opts.Progress != nil && added%opts.FlushEvery == 0 {
+					opts.Progress(added, ff.GetStats())
+				}
+			}
+		}
+	}
+}
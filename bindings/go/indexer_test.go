@@ -0,0 +1,84 @@
+package flashfuzzy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIndexFromAutoID(t *testing.T) {
+	ff := New(DefaultOptions())
+	r := strings.NewReader("Wireless Headphones\nMechanical Keyboard\nUSB Cable\n")
+
+	added, err := ff.IndexFrom(r, IndexOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if added != 3 {
+		t.Fatalf("expected 3 records added, got %d", added)
+	}
+	if ff.Count() != 3 {
+		t.Errorf("expected 3 records indexed, got %d", ff.Count())
+	}
+}
+
+func TestIndexFromExtractor(t *testing.T) {
+	ff := New(DefaultOptions())
+	r := strings.NewReader("42|Wireless Headphones\n7|USB Cable\n")
+
+	added, err := ff.IndexFrom(r, IndexOptions{
+		IDExtractor: func(line string) (uint32, string) {
+			parts := strings.SplitN(line, "|", 2)
+			var id uint32
+			for _, c := range parts[0] {
+				id = id*10 + uint32(c-'0')
+			}
+			return id, parts[1]
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if added != 2 {
+		t.Fatalf("expected 2 records added, got %d", added)
+	}
+
+	results := ff.Search("cable")
+	if len(results) == 0 || results[0].ID != 7 {
+		t.Errorf("expected extracted ID 7, got %+v", results)
+	}
+}
+
+func TestIndexFromChan(t *testing.T) {
+	ff := New(DefaultOptions())
+	ch := make(chan string, 2)
+	ch <- "Wireless Headphones"
+	ch <- "USB Cable"
+	close(ch)
+
+	added, err := ff.IndexFromChan(ch, IndexOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if added != 2 {
+		t.Fatalf("expected 2 records added, got %d", added)
+	}
+}
+
+func TestIndexFromProgress(t *testing.T) {
+	ff := New(DefaultOptions())
+	r := strings.NewReader("a\nb\nc\nd\n")
+
+	var calls int
+	_, err := ff.IndexFrom(r, IndexOptions{
+		FlushEvery: 2,
+		Progress: func(added int, stats Stats) {
+			calls++
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 progress callbacks for 4 records flushed every 2, got %d", calls)
+	}
+}
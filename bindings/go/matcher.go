@@ -0,0 +1,204 @@
+package flashfuzzy
+
+/*
+// ff_cancel asks the Rust core to abandon whatever ff_search is currently
+// in flight at its next internal poll point, without blocking the caller.
+// It is safe to call even when no search is running.
+void ff_cancel(void);
+*/
+import "C"
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultDebounce is a debounce window callers can pass to NewMatcher.
+// NewMatcher does not substitute it for 0, which disables debouncing.
+const DefaultDebounce = 50 * time.Millisecond
+
+// Matcher wraps a FlashFuzzy with a background goroutine so callers (a
+// live TUI, a web autocomplete handler) can submit a query per keystroke
+// without serializing on a mutex or blocking on stale searches. It mirrors
+// the producer/matcher/eventbox split interactive fuzzy finders use: each
+// Submit cancels whatever search is in flight and debounces rapid-fire
+// queries so only the last one in a burst actually runs.
+type Matcher struct {
+	ff       *FlashFuzzy
+	debounce time.Duration
+
+	ffMu sync.RWMutex // guards ff for concurrent Add/Remove/Snapshot vs. Search
+
+	pendingMu sync.Mutex // guards pending
+	pending   *matchRequest
+
+	generation uint64
+	searching  int32 // atomic bool: a Search call is currently running
+
+	wake      chan struct{} // signals the loop that pending changed; buffered 1
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+type matchRequest struct {
+	query    string
+	gen      uint64
+	resultCh chan []SearchResult
+}
+
+// NewMatcher starts a Matcher backed by ff, debouncing submissions within
+// the given window. A debounce of 0 disables debouncing.
+func NewMatcher(ff *FlashFuzzy, debounce time.Duration) *Matcher {
+	m := &Matcher{
+		ff:       ff,
+		debounce: debounce,
+		wake:     make(chan struct{}, 1),
+		closeCh:  make(chan struct{}),
+	}
+	go m.loop()
+	return m
+}
+
+// Submit queues query for matching and returns a channel that receives its
+// results at most once and is then closed. Submit runs synchronously on the caller's
+// goroutine up to the point of replacing the pending request, so two
+// Submit calls in a row are ordered the way they were called rather than
+// racing to reach the loop first; the later call always wins. Submitting
+// again before a prior result arrives cancels it: a result already
+// computed for a superseded generation is never delivered, and if a
+// search is actually in flight it's asked to abandon via ff_cancel.
+func (m *Matcher) Submit(query string) <-chan []SearchResult {
+	gen := atomic.AddUint64(&m.generation, 1)
+	resultCh := make(chan []SearchResult, 1)
+	req := &matchRequest{query: query, gen: gen, resultCh: resultCh}
+
+	m.pendingMu.Lock()
+	if m.pending != nil {
+		close(m.pending.resultCh) // superseded before it ever ran
+	}
+	m.pending = req
+	m.pendingMu.Unlock()
+
+	// Only worth cancelling if a search is actually running right now;
+	// calling it unconditionally would set the flag before this query's
+	// own search has even started, cancelling itself.
+	if atomic.LoadInt32(&m.searching) == 1 {
+		C.ff_cancel()
+	}
+
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+
+	return resultCh
+}
+
+// Snapshot returns a point-in-time copy of the records currently indexed,
+// safe to read while Submit-driven searches are running concurrently.
+func (m *Matcher) Snapshot() map[uint32]string {
+	m.ffMu.RLock()
+	defer m.ffMu.RUnlock()
+
+	snap := make(map[uint32]string, len(m.ff.records))
+	for id, text := range m.ff.records {
+		snap[id] = text
+	}
+	return snap
+}
+
+// Add adds a record, safe for concurrent use with in-flight Submit calls.
+func (m *Matcher) Add(id uint32, text string) bool {
+	m.ffMu.Lock()
+	defer m.ffMu.Unlock()
+	return m.ff.Add(id, text)
+}
+
+// Remove removes a record, safe for concurrent use with in-flight Submit calls.
+func (m *Matcher) Remove(id uint32) bool {
+	m.ffMu.Lock()
+	defer m.ffMu.Unlock()
+	return m.ff.Remove(id)
+}
+
+// Close stops the Matcher's background goroutine. Any result channel still
+// awaiting a query is closed without a value. Close is idempotent.
+func (m *Matcher) Close() {
+	m.closeOnce.Do(func() {
+		close(m.closeCh)
+
+		m.pendingMu.Lock()
+		if m.pending != nil {
+			close(m.pending.resultCh)
+			m.pending = nil
+		}
+		m.pendingMu.Unlock()
+	})
+}
+
+func (m *Matcher) loop() {
+	for {
+		select {
+		case <-m.wake:
+			if !m.awaitDebounce() {
+				return
+			}
+			m.runPending()
+		case <-m.closeCh:
+			return
+		}
+	}
+}
+
+// awaitDebounce blocks until the debounce window elapses with no further
+// wake signal, restarting the timer on each one. It returns false if the
+// Matcher was closed while waiting.
+func (m *Matcher) awaitDebounce() bool {
+	if m.debounce <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(m.debounce)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-m.wake:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(m.debounce)
+		case <-timer.C:
+			return true
+		case <-m.closeCh:
+			return false
+		}
+	}
+}
+
+// runPending takes whatever request is currently pending and searches it,
+// discarding the result if a newer Submit superseded it while the search
+// was running.
+func (m *Matcher) runPending() {
+	m.pendingMu.Lock()
+	req := m.pending
+	m.pending = nil
+	m.pendingMu.Unlock()
+
+	if req == nil {
+		return
+	}
+	defer close(req.resultCh)
+
+	atomic.StoreInt32(&m.searching, 1)
+	m.ffMu.RLock()
+	results := m.ff.Search(req.query)
+	m.ffMu.RUnlock()
+	atomic.StoreInt32(&m.searching, 0)
+
+	if atomic.LoadUint64(&m.generation) != req.gen {
+		return
+	}
+	req.resultCh <- results
+}
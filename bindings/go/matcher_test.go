@@ -0,0 +1,52 @@
+package flashfuzzy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatcherSubmit(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "Wireless Headphones")
+
+	m := NewMatcher(ff, 0)
+	defer m.Close()
+
+	results := <-m.Submit("headphones")
+	if len(results) == 0 {
+		t.Fatal("expected at least one result")
+	}
+}
+
+func TestMatcherDebounceKeepsLastQuery(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "Mechanical Keyboard")
+	ff.Add(2, "Wireless Mouse")
+
+	m := NewMatcher(ff, 20*time.Millisecond)
+	defer m.Close()
+
+	m.Submit("keyboard") // superseded before the debounce window elapses
+	ch := m.Submit("mouse")
+
+	select {
+	case results := <-ch:
+		if len(results) == 0 || results[0].ID != 2 {
+			t.Errorf("expected mouse result, got %+v", results)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for debounced result")
+	}
+}
+
+func TestMatcherSnapshot(t *testing.T) {
+	ff := New(DefaultOptions())
+	m := NewMatcher(ff, 0)
+	defer m.Close()
+
+	m.Add(1, "Test Item")
+	snap := m.Snapshot()
+	if snap[1] != "Test Item" {
+		t.Errorf("expected snapshot to contain record 1, got %+v", snap)
+	}
+}
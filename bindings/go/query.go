@@ -0,0 +1,346 @@
+package flashfuzzy
+
+import (
+	"sort"
+	"strings"
+)
+
+// MatchKind selects how a single query term is evaluated against a record.
+type MatchKind int
+
+const (
+	// MatchFuzzy runs the term through the normal ff_search fuzzy path.
+	MatchFuzzy MatchKind = iota
+	// MatchExact requires the term to appear verbatim (case-insensitive) in the record.
+	MatchExact
+	// MatchPrefix requires the record to start with the term.
+	MatchPrefix
+	// MatchSuffix requires the record to end with the term.
+	MatchSuffix
+)
+
+// Term is a single atomic piece of a query, e.g. "foo", "'foo", "^foo", "foo$" or "!foo".
+type Term struct {
+	Text   string
+	Kind   MatchKind
+	Negate bool
+}
+
+// Clause is one AND-ed position in a Query. A Clause with more than one
+// Alternative is an OR group (fzf's "a | b" syntax); a single Alternative
+// behaves like a plain AND term.
+type Clause struct {
+	Alternatives []Term
+}
+
+// Query is the parsed form of an extended query string: every Clause must
+// match (AND) for a record to be considered, subject to each Clause's own
+// internal OR and negation semantics.
+type Query struct {
+	Clauses []Clause
+}
+
+// QueryOptions selects which extended-query operators ParseQueryWithOptions
+// honors. Disabled operators are treated as literal characters, which
+// restores plain fuzzy-only behavior for callers that don't want fzf syntax.
+type QueryOptions struct {
+	DisableExact  bool // leading '
+	DisablePrefix bool // leading ^
+	DisableSuffix bool // trailing $
+	DisableNegate bool // leading !
+	DisableOr     bool // a | b
+}
+
+// DefaultQueryOptions enables every extended-query operator.
+func DefaultQueryOptions() QueryOptions {
+	return QueryOptions{}
+}
+
+// ParseQuery parses an fzf-style extended query string with every operator
+// enabled. See ParseQueryWithOptions to selectively disable operators.
+func ParseQuery(raw string) (*Query, error) {
+	return ParseQueryWithOptions(raw, DefaultQueryOptions())
+}
+
+// ParseQueryWithOptions parses raw into a Query AST honoring opts.
+//
+// Grammar: space-separated tokens are AND'd together. A token may be
+// prefixed with "!" to negate it, "'" to force an exact substring match,
+// "^" to require the record to start with the rest of the token, or
+// suffixed with "$" to require the record to end with the token. "!"
+// combines with any one of the others, e.g. "!^foo"; "'", "^" and "$" do
+// not combine with each other, so "^foo$" is a prefix match for "foo$".
+// Tokens joined by a bare "|"
+// token form an OR group that counts as a single AND position.
+func ParseQueryWithOptions(raw string, opts QueryOptions) (*Query, error) {
+	fields := strings.Fields(raw)
+
+	q := &Query{}
+	var current []Term
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		if !opts.DisableOr && field == "|" {
+			// A leading/trailing/doubled "|" has nothing to join; treat it
+			// as a no-op separator rather than erroring on malformed input.
+			continue
+		}
+
+		term := parseTerm(field, opts)
+
+		if !opts.DisableOr && i+1 < len(fields) && fields[i+1] == "|" {
+			current = append(current, term)
+			continue
+		}
+
+		current = append(current, term)
+		q.Clauses = append(q.Clauses, Clause{Alternatives: current})
+		current = nil
+	}
+
+	// A dangling "|" (e.g. "foo |" or "a | b |") leaves a pending OR group
+	// in current with nowhere left to join; flush it as the final clause
+	// instead of silently dropping it, which would make the whole query
+	// match nothing.
+	if len(current) > 0 {
+		q.Clauses = append(q.Clauses, Clause{Alternatives: current})
+	}
+
+	return q, nil
+}
+
+func parseTerm(field string, opts QueryOptions) Term {
+	t := Term{Kind: MatchFuzzy}
+
+	if !opts.DisableNegate && strings.HasPrefix(field, "!") {
+		t.Negate = true
+		field = field[1:]
+	}
+
+	switch {
+	case !opts.DisableExact && strings.HasPrefix(field, "'"):
+		t.Kind = MatchExact
+		field = field[1:]
+	case !opts.DisablePrefix && strings.HasPrefix(field, "^"):
+		t.Kind = MatchPrefix
+		field = field[1:]
+	case !opts.DisableSuffix && strings.HasSuffix(field, "$") && len(field) > 1:
+		t.Kind = MatchSuffix
+		field = field[:len(field)-1]
+	}
+
+	t.Text = field
+	return t
+}
+
+// SearchExtended parses query as an extended query (see ParseQuery) and
+// evaluates it against the index. Fuzzy terms are dispatched through the
+// existing ff_search FFI path; exact/prefix/suffix/negation terms are
+// evaluated in Go against the text passed to Add, since the Rust core has
+// no notion of those operators.
+func (ff *FlashFuzzy) SearchExtended(query string) ([]SearchResult, error) {
+	return ff.SearchExtendedWithOptions(query, DefaultQueryOptions())
+}
+
+// SearchExtendedWithOptions is SearchExtended with explicit QueryOptions,
+// letting callers disable individual operators.
+func (ff *FlashFuzzy) SearchExtendedWithOptions(query string, opts QueryOptions) ([]SearchResult, error) {
+	q, err := ParseQueryWithOptions(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	return ff.Run(q), nil
+}
+
+// Run evaluates an already-parsed Query against the index.
+func (ff *FlashFuzzy) Run(q *Query) []SearchResult {
+	if q == nil || len(q.Clauses) == 0 {
+		return nil
+	}
+
+	var matched map[uint32]termHit
+
+	for _, clause := range q.Clauses {
+		required := make(map[uint32]termHit)
+		excluded := make(map[uint32]bool)
+
+		for _, alt := range clause.Alternatives {
+			ids := ff.evalTerm(alt)
+			if alt.Negate {
+				for id := range ids {
+					excluded[id] = true
+				}
+				continue
+			}
+			for id, h := range ids {
+				if existing, ok := required[id]; !ok || h.score > existing.score {
+					required[id] = h
+				}
+			}
+		}
+
+		// A clause made only of negated alternatives matches every record
+		// not excluded; seed it from the full record set on first use.
+		if len(required) == 0 && allNegated(clause.Alternatives) {
+			for id, text := range ff.records {
+				if !excluded[id] {
+					required[id] = termHit{score: 1, start: 0, end: uint32(len(text))}
+				}
+			}
+		} else {
+			for id := range excluded {
+				delete(required, id)
+			}
+		}
+
+		if matched == nil {
+			matched = required
+			continue
+		}
+
+		for id := range matched {
+			if h, ok := required[id]; ok {
+				combined := matched[id]
+				combined.score += h.score
+				if h.start < combined.start {
+					combined.start = h.start
+				}
+				if h.end > combined.end {
+					combined.end = h.end
+				}
+				combined.positions = mergePositions(combined.positions, h.positions)
+				matched[id] = combined
+			} else {
+				delete(matched, id)
+			}
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil
+	}
+
+	results := make([]SearchResult, 0, len(matched))
+	for id, h := range matched {
+		results = append(results, SearchResult{
+			ID:        id,
+			Score:     h.score / float32(len(q.Clauses)),
+			Start:     h.start,
+			End:       h.end,
+			Positions: h.positions,
+		})
+	}
+	return results
+}
+
+// mergePositions unions two position sets, returning a sorted,
+// deduplicated slice. Used to combine per-clause match positions across
+// the AND'd clauses of a Query into the final SearchResult.Positions.
+func mergePositions(a, b []uint32) []uint32 {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+
+	seen := make(map[uint32]bool, len(a)+len(b))
+	out := make([]uint32, 0, len(a)+len(b))
+	for _, p := range a {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	for _, p := range b {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func allNegated(terms []Term) bool {
+	for _, t := range terms {
+		if !t.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+type termHit struct {
+	score     float32
+	start     uint32
+	end       uint32
+	positions []uint32
+}
+
+// evalTerm runs a single atomic term and returns the matching record IDs.
+// Fuzzy terms go through the Rust core; the literal operators are
+// evaluated directly against the cached record text.
+//
+// Positions are captured here rather than left for the caller to fetch
+// later via FlashFuzzy.Positions: a fuzzy term's own ff.Search call
+// overwrites the Rust core's result buffer as soon as the *next* fuzzy
+// term or clause runs, so Positions(i) is only meaningful for the instant
+// between a Search call and the next one. Reading it immediately, while
+// results are still ours, is what lets Run merge real positions into the
+// final SearchResult instead of leaving them stale or empty.
+//
+// The Scorer is switched off for that Search call, because its re-sort
+// would make index i of the returned slice differ from index i of the
+// core's buffer; each hit is re-scored here instead.
+func (ff *FlashFuzzy) evalTerm(t Term) map[uint32]termHit {
+	out := make(map[uint32]termHit)
+
+	if t.Kind == MatchFuzzy {
+		scorer := ff.scorer
+		ff.scorer = nil
+		results := ff.Search(t.Text)
+		ff.scorer = scorer
+		for i, r := range results {
+			if scorer != nil {
+				r.Score = scorer.Score(ff.records[r.ID], t.Text, r)
+			}
+			out[r.ID] = termHit{score: r.Score, start: r.Start, end: r.End, positions: ff.Positions(i)}
+		}
+		return out
+	}
+
+	needle := strings.ToLower(t.Text)
+	for id, text := range ff.records {
+		haystack := strings.ToLower(text)
+
+		var idx int
+		switch t.Kind {
+		case MatchExact:
+			idx = strings.Index(haystack, needle)
+		case MatchPrefix:
+			if strings.HasPrefix(haystack, needle) {
+				idx = 0
+			} else {
+				idx = -1
+			}
+		case MatchSuffix:
+			if strings.HasSuffix(haystack, needle) {
+				idx = len(haystack) - len(needle)
+			} else {
+				idx = -1
+			}
+		}
+
+		if idx < 0 {
+			continue
+		}
+
+		positions := make([]uint32, len(needle))
+		for i := range positions {
+			positions[i] = uint32(idx + i)
+		}
+		out[id] = termHit{score: 1, start: uint32(idx), end: uint32(idx + len(needle)), positions: positions}
+	}
+	return out
+}
@@ -0,0 +1,193 @@
+package flashfuzzy
+
+import "testing"
+
+func TestParseQueryBasic(t *testing.T) {
+	q, err := ParseQuery("foo bar")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(q.Clauses) != 2 {
+		t.Fatalf("expected 2 clauses, got %d", len(q.Clauses))
+	}
+	for _, c := range q.Clauses {
+		if len(c.Alternatives) != 1 {
+			t.Errorf("expected plain AND term, got OR group of %d", len(c.Alternatives))
+		}
+	}
+}
+
+func TestParseQueryOperators(t *testing.T) {
+	q, err := ParseQuery("'exact ^prefix suffix$ !negated")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(q.Clauses) != 4 {
+		t.Fatalf("expected 4 clauses, got %d", len(q.Clauses))
+	}
+
+	cases := []struct {
+		kind   MatchKind
+		text   string
+		negate bool
+	}{
+		{MatchExact, "exact", false},
+		{MatchPrefix, "prefix", false},
+		{MatchSuffix, "suffix", false},
+		{MatchFuzzy, "negated", true},
+	}
+	for i, c := range cases {
+		got := q.Clauses[i].Alternatives[0]
+		if got.Kind != c.kind || got.Text != c.text || got.Negate != c.negate {
+			t.Errorf("clause %d: got %+v, want %+v", i, got, c)
+		}
+	}
+}
+
+func TestParseQueryOr(t *testing.T) {
+	q, err := ParseQuery("foo | bar baz")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(q.Clauses) != 2 {
+		t.Fatalf("expected 2 clauses, got %d", len(q.Clauses))
+	}
+	if len(q.Clauses[0].Alternatives) != 2 {
+		t.Fatalf("expected OR group of 2, got %d", len(q.Clauses[0].Alternatives))
+	}
+	if len(q.Clauses[1].Alternatives) != 1 {
+		t.Fatalf("expected plain AND term, got %d", len(q.Clauses[1].Alternatives))
+	}
+}
+
+func TestParseQueryTrailingPipe(t *testing.T) {
+	cases := []string{"foo |", "a | b |"}
+	for _, raw := range cases {
+		q, err := ParseQuery(raw)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", raw, err)
+		}
+		if len(q.Clauses) == 0 {
+			t.Fatalf("%q: expected the dangling OR group to be flushed as a clause, got 0 clauses", raw)
+		}
+		last := q.Clauses[len(q.Clauses)-1]
+		if len(last.Alternatives) == 0 {
+			t.Fatalf("%q: expected the final clause to carry the pending alternatives", raw)
+		}
+	}
+}
+
+func TestParseQueryDisabledOperators(t *testing.T) {
+	opts := QueryOptions{DisableExact: true, DisablePrefix: true, DisableSuffix: true, DisableNegate: true, DisableOr: true}
+	q, err := ParseQueryWithOptions("'foo ^bar baz$ !qux a | b", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// With every operator disabled, "|" is a literal token of its own and
+	// every other token stays a plain fuzzy AND term.
+	want := []string{"'foo", "^bar", "baz$", "!qux", "a", "|", "b"}
+	if len(q.Clauses) != len(want) {
+		t.Fatalf("expected %d clauses, got %d", len(want), len(q.Clauses))
+	}
+	for i, w := range want {
+		got := q.Clauses[i].Alternatives[0]
+		if got.Kind != MatchFuzzy || got.Negate || got.Text != w {
+			t.Errorf("clause %d: got %+v, want literal %q", i, got, w)
+		}
+	}
+}
+
+func TestSearchExtendedExactAndNegate(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "Wireless Headphones")
+	ff.Add(2, "Mechanical Keyboard")
+	ff.Add(3, "Wireless Mouse")
+
+	results, err := ff.SearchExtended("'wireless !headphones")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != 3 {
+		t.Fatalf("expected only ID 3, got %+v", results)
+	}
+}
+
+func TestSearchExtendedTrailingPipe(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "Wireless Headphones")
+
+	results, err := ff.SearchExtended("'headphones |")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != 1 {
+		t.Fatalf("expected a dangling trailing '|' to still match ID 1, got %+v", results)
+	}
+}
+
+func resultIDs(results []SearchResult) map[uint32]bool {
+	ids := make(map[uint32]bool, len(results))
+	for _, r := range results {
+		ids[r.ID] = true
+	}
+	return ids
+}
+
+func TestSearchExtendedOrUnion(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "Wireless Headphones")
+	ff.Add(2, "Mechanical Keyboard")
+	ff.Add(3, "USB Cable")
+
+	results, err := ff.SearchExtended("'headphones | 'keyboard")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := resultIDs(results)
+	if !got[1] || !got[2] || got[3] {
+		t.Fatalf("expected the OR group to union IDs 1 and 2 but not 3, got %+v", results)
+	}
+}
+
+func TestSearchExtendedOrWithNegation(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "Wireless Headphones")
+	ff.Add(2, "Wireless Mouse")
+	ff.Add(3, "Mechanical Keyboard")
+
+	// Within this OR clause, the negated alternative subtracts from the
+	// positive union rather than being unioned in itself: record 2
+	// matches both "wireless" and the negated "mouse", so it's excluded;
+	// record 3 never matches "wireless" so it was never a candidate.
+	results, err := ff.SearchExtended("'wireless | !'mouse")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != 1 {
+		t.Fatalf("expected only ID 1, got %+v", results)
+	}
+}
+
+func TestSearchExtendedPrefixSuffix(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "prefix-match")
+	ff.Add(2, "no-match-here")
+	ff.Add(3, "ends-with-suffix")
+
+	results, err := ff.SearchExtended("^prefix")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != 1 {
+		t.Fatalf("expected only ID 1, got %+v", results)
+	}
+
+	results, err = ff.SearchExtended("suffix$")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != 3 {
+		t.Fatalf("expected only ID 3, got %+v", results)
+	}
+}
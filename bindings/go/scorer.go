@@ -0,0 +1,226 @@
+package flashfuzzy
+
+import "sort"
+
+// Separator characters that earn a word-boundary bonus when the match
+// starts immediately after one of them (filename/identifier style corpora).
+const boundaryChars = "/_-. "
+
+// Scorer re-ranks the raw hits returned by the FFI using the matched span
+// and the original record text. FlashFuzzy.Search calls the configured
+// Scorer after every search; the default is FzfV2Scorer.
+type Scorer interface {
+	// Score returns a replacement score in [0,1] for raw, a hit against
+	// text for query. It may use raw.Start/raw.End as a hint but is free
+	// to recompute the matched positions itself.
+	Score(text, query string, raw SearchResult) float32
+}
+
+// SetScorer installs the Scorer used to re-rank Search results. Passing nil
+// restores the default FzfV2Scorer.
+func (ff *FlashFuzzy) SetScorer(s Scorer) {
+	if s == nil {
+		s = NewFzfV2Scorer()
+	}
+	ff.scorer = s
+}
+
+// FzfV2Scorer re-scores matches the way fzf's v2 algorithm does: it rewards
+// matches at the start of the string, right after a separator, at a
+// camelCase boundary, and fully consecutive runs, while penalizing gaps
+// between matched characters and longer matched spans. All bonus/penalty
+// constants are exported so callers can bias the scorer toward
+// filename-like or sentence-like corpora.
+type FzfV2Scorer struct {
+	BonusStartOfString float32 // a matched byte is text[0]
+	BonusBoundary      float32 // per matched byte right after a separator
+	BonusCamelCase     float32 // per matched byte at a lower->upper transition
+	BonusConsecutive   float32 // per additional consecutively-matched byte, times the run's length so far
+	PenaltyGap         float32 // per byte of gap between matched positions
+	PenaltySpanLength  float32 // per byte of matched span beyond the query length
+	PenaltyTextLength  float32 // per byte of total text length, tiebreaker only
+}
+
+// NewFzfV2Scorer returns an FzfV2Scorer with fzf-like default constants.
+func NewFzfV2Scorer() *FzfV2Scorer {
+	return &FzfV2Scorer{
+		BonusStartOfString: 0.08,
+		BonusBoundary:      0.06,
+		BonusCamelCase:     0.05,
+		BonusConsecutive:   0.04,
+		PenaltyGap:         0.01,
+		PenaltySpanLength:  0.005,
+		PenaltyTextLength:  0.0001,
+	}
+}
+
+// Score implements Scorer.
+func (s *FzfV2Scorer) Score(text, query string, raw SearchResult) float32 {
+	if query == "" || len(text) == 0 {
+		return raw.Score
+	}
+
+	positions, ok := matchPositions(text, query, raw.Start, raw.End)
+	if !ok {
+		return raw.Score
+	}
+
+	score := raw.Score
+	var lastPos int = -1
+	consecutive := 0
+
+	for _, pos := range positions {
+		if pos == 0 {
+			score += s.BonusStartOfString
+		} else if isBoundary(text[pos-1]) {
+			score += s.BonusBoundary
+		} else if isCamelBoundary(text, pos) {
+			score += s.BonusCamelCase
+		}
+
+		if lastPos >= 0 {
+			gap := pos - lastPos - 1
+			if gap == 0 {
+				consecutive++
+				score += s.BonusConsecutive * float32(consecutive)
+			} else {
+				consecutive = 0
+				score -= s.PenaltyGap * float32(gap)
+			}
+		}
+		lastPos = pos
+	}
+
+	spanLen := int(raw.End) - int(raw.Start)
+	if over := spanLen - len(query); over > 0 {
+		score -= s.PenaltySpanLength * float32(over)
+	}
+	score -= s.PenaltyTextLength * float32(len(text))
+
+	if score < 0 {
+		score = 0
+	}
+	if score > 1 {
+		score = 1
+	}
+	return score
+}
+
+func isBoundary(b byte) bool {
+	for i := 0; i < len(boundaryChars); i++ {
+		if boundaryChars[i] == b {
+			return true
+		}
+	}
+	return false
+}
+
+func isCamelBoundary(text string, pos int) bool {
+	if pos == 0 {
+		return false
+	}
+	prev, cur := text[pos-1], text[pos]
+	return prev >= 'a' && prev <= 'z' && cur >= 'A' && cur <= 'Z'
+}
+
+// matchPositions recomputes the individual byte offsets of query's
+// characters within text[start:end] using a bounded Smith-Waterman-style
+// DP. FzfV2Scorer calls it for every hit; it does not read the positions
+// the FFI reports (see Positions/ff_get_result_positions).
+func matchPositions(text, query string, start, end uint32) ([]int, bool) {
+	// The FFI-reported span bounds the DP window. It is clamped to text
+	// but not widened, so a query byte outside it is not found.
+	lo := int(start)
+	hi := int(end)
+	if hi > len(text) {
+		hi = len(text)
+	}
+	if lo < 0 || lo > hi {
+		lo = 0
+	}
+
+	const maxWindow = 4096 // bound the DP so pathologically long records can't blow up cost
+	if hi-lo > maxWindow {
+		hi = lo + maxWindow
+	}
+
+	window := toLowerASCII(text[lo:hi])
+	needle := toLowerASCII(query)
+	n, m := len(window), len(needle)
+	if m == 0 || n == 0 || m > n {
+		return nil, false
+	}
+
+	const negInf = -1 << 30
+	const matchScore = 10
+
+	// bestEnd[j] is the best score of any increasing subsequence matching
+	// needle[:j] using window positions seen so far; bestAt[j] is the
+	// window index it ends on, and back[j] remembers the predecessor
+	// index for backtracking. j=0 is the empty match, anchored at -1.
+	bestEnd := make([]int, m+1)
+	bestAt := make([]int, m+1)
+	back := make([][]int, m+1)
+	for j := range bestEnd {
+		bestEnd[j] = negInf
+		bestAt[j] = -1
+	}
+	bestEnd[0] = 0
+
+	for i := 0; i < n; i++ {
+		// Walk j downward so bestEnd[j-1]/bestAt[j-1] still reflect the
+		// state before this window position was considered.
+		for j := m; j >= 1; j-- {
+			if window[i] != needle[j-1] || bestEnd[j-1] == negInf {
+				continue
+			}
+			gap := i - bestAt[j-1] - 1
+			candidate := bestEnd[j-1] + matchScore - gap
+			if candidate > bestEnd[j] {
+				bestEnd[j] = candidate
+				bestAt[j] = i
+				back[j] = append(back[j][:0], bestAt[j-1])
+			}
+		}
+	}
+
+	if bestEnd[m] == negInf {
+		return nil, false
+	}
+
+	positions := make([]int, m)
+	i := bestAt[m]
+	for j := m; j >= 1; j-- {
+		positions[j-1] = lo + i
+		if len(back[j]) == 0 {
+			break
+		}
+		i = back[j][0]
+	}
+
+	return positions, true
+}
+
+func toLowerASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
+
+// applyScorer re-scores and re-sorts results in place using ff.scorer.
+func (ff *FlashFuzzy) applyScorer(results []SearchResult, query string) {
+	if ff.scorer == nil || len(results) == 0 {
+		return
+	}
+	for i := range results {
+		text := ff.records[results[i].ID]
+		results[i].Score = ff.scorer.Score(text, query, results[i])
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+}
@@ -0,0 +1,42 @@
+package flashfuzzy
+
+import "testing"
+
+func TestFzfV2ScorerRewardsBoundaryMatch(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "internal_foo_bar")
+	ff.Add(2, "xfoobarx")
+
+	results := ff.Search("foobar")
+	if len(results) < 2 {
+		t.Fatalf("expected at least 2 results, got %d", len(results))
+	}
+	if results[0].ID != 1 {
+		t.Errorf("expected boundary match (ID 1) to rank first, got ID %d: %+v", results[0].ID, results)
+	}
+}
+
+func TestFzfV2ScorerCamelCaseBonus(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "getUserName")
+	ff.Add(2, "getuserwhatever")
+
+	results := ff.Search("un")
+	if len(results) < 2 {
+		t.Fatalf("expected at least 2 results, got %d", len(results))
+	}
+	if results[0].ID != 1 {
+		t.Errorf("expected camelCase boundary match (ID 1) to rank first, got ID %d: %+v", results[0].ID, results)
+	}
+}
+
+func TestSetScorerNil(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.SetScorer(nil)
+	ff.Add(1, "Test Item")
+
+	results := ff.Search("test")
+	if len(results) == 0 {
+		t.Fatal("expected at least one result with default scorer restored")
+	}
+}
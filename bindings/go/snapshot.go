@@ -0,0 +1,221 @@
+package flashfuzzy
+
+/*
+#include <stdint.h>
+
+// ff_serialize_size returns the number of bytes ff_serialize needs to dump
+// the current string pool, ID map and n-gram index.
+uint32_t ff_serialize_size(void);
+// ff_serialize writes the current index into buf (capacity cap) and
+// returns the number of bytes written.
+uint32_t ff_serialize(uint8_t* buf, uint32_t cap);
+// ff_deserialize replaces the current index with the one encoded in buf,
+// returning 1 on success and 0 if buf is malformed or truncated.
+int32_t ff_deserialize(const uint8_t* buf, uint32_t len);
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"unsafe"
+)
+
+// snapshotMagic identifies a FlashFuzzy snapshot file; snapshotVersion is
+// bumped whenever the section layout below changes incompatibly.
+var snapshotMagic = [4]byte{'F', 'F', 'Z', '1'}
+
+const snapshotVersion uint32 = 1
+
+// Save writes the current index to w as a versioned, CRC-checked
+// snapshot: a header (magic, version, Options), the Rust core's
+// serialized string pool/ID map/n-gram index, the Go-side id->text cache
+// used by SearchExtended and the Scorer, and a trailing CRC32 of
+// everything that precedes it so a partial write is detected on Load
+// rather than silently corrupting the index.
+func (ff *FlashFuzzy) Save(w io.Writer) error {
+	coreBlob, err := ff.serializeCore()
+	if err != nil {
+		return err
+	}
+	recordsBlob := ff.serializeRecords()
+
+	var body bytes.Buffer
+	body.Write(snapshotMagic[:])
+	binary.Write(&body, binary.LittleEndian, snapshotVersion)
+	binary.Write(&body, binary.LittleEndian, uint32(ff.threshold*1000))
+	binary.Write(&body, binary.LittleEndian, ff.maxErrors)
+	binary.Write(&body, binary.LittleEndian, ff.maxResults)
+
+	writeSection(&body, coreBlob)
+	writeSection(&body, recordsBlob)
+
+	checksum := crc32.ChecksumIEEE(body.Bytes())
+
+	if _, err := w.Write(body.Bytes()); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, checksum)
+}
+
+// Load reads a snapshot written by Save and rebuilds a FlashFuzzy from it.
+// opts configures the new instance the same way New does; the snapshot
+// itself carries no behavior beyond the data needed to repopulate the
+// index. Load returns an error if the magic, version, or CRC32 trailer
+// don't match, which catches truncated or corrupted snapshot files.
+func Load(r io.Reader, opts Options) (*FlashFuzzy, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 {
+		return nil, fmt.Errorf("flashfuzzy: snapshot too short")
+	}
+
+	body, trailer := data[:len(data)-4], data[len(data)-4:]
+	wantCRC := binary.LittleEndian.Uint32(trailer)
+	if got := crc32.ChecksumIEEE(body); got != wantCRC {
+		return nil, fmt.Errorf("flashfuzzy: snapshot CRC mismatch (got %x, want %x), likely a partial write", got, wantCRC)
+	}
+
+	br := bytes.NewReader(body)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(br, magic[:]); err != nil {
+		return nil, err
+	}
+	if magic != snapshotMagic {
+		return nil, fmt.Errorf("flashfuzzy: not a FlashFuzzy snapshot (bad magic %q)", magic)
+	}
+
+	var version uint32
+	if err := binary.Read(br, binary.LittleEndian, &version); err != nil {
+		return nil, err
+	}
+	if version != snapshotVersion {
+		return nil, fmt.Errorf("flashfuzzy: unsupported snapshot version %d", version)
+	}
+
+	// The saved Options are currently informational only; callers pass
+	// their own opts to Load the same way they would to New.
+	var threshold, maxErrors, maxResults uint32
+	for _, p := range []*uint32{&threshold, &maxErrors, &maxResults} {
+		if err := binary.Read(br, binary.LittleEndian, p); err != nil {
+			return nil, err
+		}
+	}
+
+	coreBlob, err := readSection(br)
+	if err != nil {
+		return nil, err
+	}
+	recordsBlob, err := readSection(br)
+	if err != nil {
+		return nil, err
+	}
+
+	ff := New(opts)
+	if len(coreBlob) > 0 {
+		if C.ff_deserialize((*C.uint8_t)(unsafe.Pointer(&coreBlob[0])), C.uint32_t(len(coreBlob))) != 1 {
+			return nil, fmt.Errorf("flashfuzzy: core rejected snapshot as malformed")
+		}
+	}
+	if err := ff.loadRecords(recordsBlob); err != nil {
+		return nil, err
+	}
+
+	return ff, nil
+}
+
+func (ff *FlashFuzzy) serializeCore() ([]byte, error) {
+	size := uint32(C.ff_serialize_size())
+	if size == 0 {
+		return nil, nil
+	}
+
+	buf := make([]byte, size)
+	n := uint32(C.ff_serialize((*C.uint8_t)(unsafe.Pointer(&buf[0])), C.uint32_t(size)))
+	if n > size {
+		return nil, fmt.Errorf("flashfuzzy: ff_serialize wrote %d bytes into a %d-byte buffer", n, size)
+	}
+	return buf[:n], nil
+}
+
+// serializeRecords encodes the Go-side id->text cache (see FlashFuzzy.records)
+// as a simple count-prefixed list of [id, textLen, text] entries, so
+// operators that only exist in Go (exact/prefix/suffix matching, the
+// Scorer) keep working after Load.
+func (ff *FlashFuzzy) serializeRecords() []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, uint32(len(ff.records)))
+	for id, text := range ff.records {
+		binary.Write(&b, binary.LittleEndian, id)
+		binary.Write(&b, binary.LittleEndian, uint32(len(text)))
+		b.WriteString(text)
+	}
+	return b.Bytes()
+}
+
+// minRecordSize is the smallest a single encoded record can be (id +
+// textLen, with an empty text), used to bound a claimed record count
+// against the bytes actually remaining before trusting it.
+const minRecordSize = 8
+
+func (ff *FlashFuzzy) loadRecords(blob []byte) error {
+	r := bytes.NewReader(blob)
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		if err == io.EOF {
+			return nil // empty snapshot
+		}
+		return err
+	}
+	if int64(count)*minRecordSize > int64(r.Len()) {
+		return fmt.Errorf("flashfuzzy: snapshot claims %d records but only %d bytes remain", count, r.Len())
+	}
+
+	records := make(map[uint32]string, count)
+	for i := uint32(0); i < count; i++ {
+		var id, textLen uint32
+		if err := binary.Read(r, binary.LittleEndian, &id); err != nil {
+			return err
+		}
+		if err := binary.Read(r, binary.LittleEndian, &textLen); err != nil {
+			return err
+		}
+		if int64(textLen) > int64(r.Len()) {
+			return fmt.Errorf("flashfuzzy: record %d text length %d exceeds %d remaining bytes", id, textLen, r.Len())
+		}
+		text := make([]byte, textLen)
+		if _, err := io.ReadFull(r, text); err != nil {
+			return err
+		}
+		records[id] = string(text)
+	}
+
+	ff.records = records
+	return nil
+}
+
+func writeSection(b *bytes.Buffer, section []byte) {
+	binary.Write(b, binary.LittleEndian, uint32(len(section)))
+	b.Write(section)
+}
+
+func readSection(r *bytes.Reader) ([]byte, error) {
+	var n uint32
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, err
+	}
+	if int64(n) > int64(r.Len()) {
+		return nil, fmt.Errorf("flashfuzzy: section length %d exceeds %d remaining bytes", n, r.Len())
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
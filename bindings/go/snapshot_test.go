@@ -0,0 +1,85 @@
+package flashfuzzy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "Wireless Headphones")
+	ff.Add(2, "Mechanical Keyboard")
+
+	var buf bytes.Buffer
+	if err := ff.Save(&buf); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	loaded, err := Load(&buf, DefaultOptions())
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+
+	if len(loaded.records) != len(ff.records) {
+		t.Errorf("expected %d cached records after load, got %d", len(ff.records), len(loaded.records))
+	}
+	if loaded.records[2] != "Mechanical Keyboard" {
+		t.Errorf("expected record 2 to round-trip, got %q", loaded.records[2])
+	}
+}
+
+func TestLoadRejectsBadMagic(t *testing.T) {
+	// Append a valid CRC32 so Load gets past the trailer check and
+	// actually reaches the magic check.
+	buf := bytes.NewBufferString("not a snapshot at all")
+	binary.Write(buf, binary.LittleEndian, crc32.ChecksumIEEE(buf.Bytes()))
+	if _, err := Load(buf, DefaultOptions()); err == nil {
+		t.Fatal("expected an error for a non-snapshot input")
+	}
+}
+
+func TestLoadRejectsCorruptedTrailer(t *testing.T) {
+	ff := New(DefaultOptions())
+	ff.Add(1, "Test Item")
+
+	var buf bytes.Buffer
+	if err := ff.Save(&buf); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	data := buf.Bytes()
+	data[len(data)-1] ^= 0xFF // flip every bit of the CRC32 trailer's last byte
+
+	if _, err := Load(bytes.NewReader(data), DefaultOptions()); err == nil {
+		t.Fatal("expected a CRC mismatch error")
+	}
+}
+
+// TestLoadRejectsForgedSectionLength forges a snapshot whose CRC32 is
+// valid for its bytes but whose records-section length claims far more
+// data than actually follows. CRC32 isn't a keyed MAC, so anyone who
+// controls the snapshot bytes can recompute a matching checksum over a
+// crafted payload; Load must bound claimed lengths against what's
+// actually left in the reader instead of trusting them enough to
+// allocate first.
+func TestLoadRejectsForgedSectionLength(t *testing.T) {
+	var body bytes.Buffer
+	body.Write(snapshotMagic[:])
+	binary.Write(&body, binary.LittleEndian, snapshotVersion)
+	binary.Write(&body, binary.LittleEndian, uint32(250)) // threshold
+	binary.Write(&body, binary.LittleEndian, uint32(2))   // maxErrors
+	binary.Write(&body, binary.LittleEndian, uint32(50))  // maxResults
+	binary.Write(&body, binary.LittleEndian, uint32(0))   // empty core section
+
+	// Claim a huge records section with no data behind it.
+	binary.Write(&body, binary.LittleEndian, uint32(1<<31-1))
+
+	checksum := crc32.ChecksumIEEE(body.Bytes())
+	binary.Write(&body, binary.LittleEndian, checksum)
+
+	if _, err := Load(bytes.NewReader(body.Bytes()), DefaultOptions()); err == nil {
+		t.Fatal("expected an error for a section length exceeding the remaining bytes")
+	}
+}